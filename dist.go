@@ -0,0 +1,134 @@
+package rand
+
+// Float64 returns a pseudorandom number in [0.0, 1.0).
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Float32 returns a pseudorandom number in [0.0, 1.0).
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Float32() float32 {
+	for {
+		// Rounding a Float64 close to 1 to float32 can yield 1.0; redraw.
+		if f := float32(r.Float64()); f < 1 {
+			return f
+		}
+	}
+}
+
+// NormFloat64 returns a normally distributed float64 with mean 0 and
+// standard deviation 1, generated via the Ziggurat method.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) NormFloat64() float64 {
+	x := ziggurat(r, normX[:], normF[:], normPDF, normTailSample)
+	if r.Uint32()&1 == 0 {
+		return -x
+	}
+	return x
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate
+// parameter (lambda) 1, generated via the Ziggurat method.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) ExpFloat64() float64 {
+	return ziggurat(r, expX[:], expF[:], expPDF, expTailSample)
+}
+
+// intn returns a pseudorandom int in [0, n), picking the narrower
+// 32-bit path when n fits, mirroring the package-level Intn.
+func (r *RNG) intn(n int) int {
+	if n <= 1<<31-1 {
+		return int(r.Uint32n(uint32(n)))
+	}
+	return int(r.Uint64n(uint64(n)))
+}
+
+// Perm returns a pseudorandom permutation of the integers [0, n) as a
+// slice, using Fisher-Yates.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Perm(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j := r.intn(i + 1)
+		p[i], p[j] = p[j], p[i]
+	}
+	return p
+}
+
+// Shuffle pseudorandomizes the order of n elements via swap, using
+// Fisher-Yates.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := r.intn(i + 1)
+		swap(i, j)
+	}
+}
+
+// Float64 returns a pseudorandom number in [0.0, 1.0).
+//
+// It is safe calling this function from concurrent goroutines.
+func Float64() float64 {
+	r, release := acquireRNG()
+	defer release()
+	return r.Float64()
+}
+
+// Float32 returns a pseudorandom number in [0.0, 1.0).
+//
+// It is safe calling this function from concurrent goroutines.
+func Float32() float32 {
+	r, release := acquireRNG()
+	defer release()
+	return r.Float32()
+}
+
+// NormFloat64 returns a normally distributed float64 with mean 0 and
+// standard deviation 1.
+//
+// It is safe calling this function from concurrent goroutines.
+func NormFloat64() float64 {
+	r, release := acquireRNG()
+	defer release()
+	return r.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate
+// parameter (lambda) 1.
+//
+// It is safe calling this function from concurrent goroutines.
+func ExpFloat64() float64 {
+	r, release := acquireRNG()
+	defer release()
+	return r.ExpFloat64()
+}
+
+// Perm returns a pseudorandom permutation of the integers [0, n).
+//
+// It is safe calling this function from concurrent goroutines.
+func Perm(n int) []int {
+	var p []int
+	withRNG(func(r *RNG) {
+		p = r.Perm(n)
+	})
+	return p
+}
+
+// Shuffle pseudorandomizes the order of n elements via swap.
+//
+// It is safe calling this function from concurrent goroutines.
+func Shuffle(n int, swap func(i, j int)) {
+	withRNG(func(r *RNG) {
+		r.Shuffle(n, swap)
+	})
+}
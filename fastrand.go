@@ -6,9 +6,8 @@
 package rand
 
 import (
+	"encoding/binary"
 	"reflect"
-	"sync"
-	"time"
 	"unsafe"
 )
 
@@ -17,30 +16,46 @@ const (
 	rngMask = rngMax - 1
 )
 
-var rngPool = sync.Pool{
-	New: func() interface{} {
-		return new(RNG)
-	},
+// withRNG runs fn against a standalone RNG seeded from a shared one
+// (a shard, or the fallback pool), for unbounded work such as Read of
+// an arbitrarily large buffer, or Perm/Shuffle driven by a
+// caller-supplied swap. The shared RNG is only touched - locked, drawn
+// from four times to derive fn's seed, unlocked - for that tiny,
+// bounded draw; it is never exposed to fn and never written back, so
+// two concurrent callers can't observe each other's starting state and
+// can't clobber a shard they no longer hold.
+func withRNG(fn func(r *RNG)) {
+	var seed [32]byte
+	r, release := acquireRNG()
+	binary.LittleEndian.PutUint64(seed[0:8], r.Uint64())
+	binary.LittleEndian.PutUint64(seed[8:16], r.Uint64())
+	binary.LittleEndian.PutUint64(seed[16:24], r.Uint64())
+	binary.LittleEndian.PutUint64(seed[24:32], r.Uint64())
+	release()
+
+	var local RNG
+	local.Seed(seed)
+	fn(&local)
 }
 
 // Uint32 returns pseudorandom uint32.
 //
 // It is safe calling this function from concurrent goroutines.
 func Uint32() uint32 {
-	r := rngPool.Get().(*RNG)
-	defer rngPool.Put(r)
+	r, release := acquireRNG()
+	defer release()
 	return r.Uint32()
 }
 
 func Uint64() uint64 {
-	r := rngPool.Get().(*RNG)
-	defer rngPool.Put(r)
+	r, release := acquireRNG()
+	defer release()
 	return r.Uint64()
 }
 
 func Uint64n(n uint64) uint64 {
-	r := rngPool.Get().(*RNG)
-	defer rngPool.Put(r)
+	r, release := acquireRNG()
+	defer release()
 	return r.Uint64n(n)
 }
 
@@ -59,19 +74,19 @@ func Read(p []byte) (int, error) {
 		}
 		return len(p), nil
 	}
-	r := rngPool.Get().(*RNG)
-	defer rngPool.Put(r)
-	var u32 []uint32
-	bh := (*reflect.SliceHeader)(unsafe.Pointer(&p))
-	uh := (*reflect.SliceHeader)(unsafe.Pointer(&u32))
-	uh.Data = bh.Data
-	uh.Len = bh.Len / 4
-	uh.Cap = uh.Cap / 4
-	r.uint32s(u32)
-	if n := uh.Len*4 - bh.Len; n != 0 {
-		uh.Data = bh.Data + uintptr(n)
-		u32[uh.Len-1] = r.Uint32()
-	}
+	withRNG(func(r *RNG) {
+		var u32 []uint32
+		bh := (*reflect.SliceHeader)(unsafe.Pointer(&p))
+		uh := (*reflect.SliceHeader)(unsafe.Pointer(&u32))
+		uh.Data = bh.Data
+		uh.Len = bh.Len / 4
+		uh.Cap = uh.Cap / 4
+		r.uint32s(u32)
+		if n := uh.Len*4 - bh.Len; n != 0 {
+			uh.Data = bh.Data + uintptr(-n)
+			u32[uh.Len-1] = r.Uint32()
+		}
+	})
 	return len(p), nil
 }
 
@@ -102,60 +117,3 @@ func Int63() int64 {
 func Int31() int32 {
 	return int32(Uint32())
 }
-
-// RNG is a pseudorandom number generator.
-//
-// It is unsafe to call RNG methods from concurrent goroutines.
-type RNG struct {
-	x uint32
-}
-
-// Uint32 returns pseudorandom uint32.
-//
-// It is unsafe to call this method from concurrent goroutines.
-func (r *RNG) Uint32() uint32 {
-	for r.x == 0 {
-		r.x = func() uint32 {
-			x := time.Now().UnixNano()
-			return uint32((x >> 32) ^ x)
-		}()
-	}
-	// See https://en.wikipedia.org/wiki/Xorshift
-	x := r.x
-	x ^= x << 13
-	x ^= x >> 17
-	x ^= x << 5
-	r.x = x
-	return x
-}
-
-func (r *RNG) Uint64() uint64 {
-	return uint64(r.Uint32())<<32 | uint64(r.Uint32())
-}
-
-// Uint32n returns pseudorandom uint32 in the range [0..maxN).
-//
-// It is unsafe to call this method from concurrent goroutines.
-func (r *RNG) Uint32n(maxN uint32) uint32 {
-	x := r.Uint32()
-	// See http://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/
-	return uint32((uint64(x) * uint64(maxN)) >> 32)
-}
-
-// Uint64n returns pseudorandom uint32 in the range [0..maxN).
-//
-// It is unsafe to call this method from concurrent goroutines.
-func (r *RNG) Uint64n(maxN uint64) uint64 {
-	return uint64(r.Uint32n(uint32(maxN>>32)))<<32 | uint64(Uint32())
-}
-
-func (r *RNG) uint32s(u32 []uint32) {
-	x := r.x
-	for i := range u32 {
-		x ^= x << 13
-		x ^= x >> 17
-		x ^= x << 5
-		u32[i] = x
-	}
-	r.x = x
-}
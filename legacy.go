@@ -0,0 +1,68 @@
+package rand
+
+import "time"
+
+// RNG32 is the original xorshift32-based generator. It is kept around
+// verbatim, bugs and all, for callers that depend on its exact wire
+// behavior; new code should use RNG instead.
+//
+// It is unsafe to call RNG32 methods from concurrent goroutines.
+type RNG32 struct {
+	x uint32
+}
+
+// Uint32 returns pseudorandom uint32.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG32) Uint32() uint32 {
+	for r.x == 0 {
+		r.x = func() uint32 {
+			x := time.Now().UnixNano()
+			return uint32((x >> 32) ^ x)
+		}()
+	}
+	// See https://en.wikipedia.org/wiki/Xorshift
+	x := r.x
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	r.x = x
+	return x
+}
+
+func (r *RNG32) Uint64() uint64 {
+	return uint64(r.Uint32())<<32 | uint64(r.Uint32())
+}
+
+// Uint32n returns pseudorandom uint32 in the range [0..maxN).
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG32) Uint32n(maxN uint32) uint32 {
+	x := r.Uint32()
+	// See http://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/
+	return uint32((uint64(x) * uint64(maxN)) >> 32)
+}
+
+// Uint64n returns pseudorandom uint64. For maxN >= 1<<32 the result is
+// below maxN; for smaller maxN it is any uint32 value.
+//
+// It is unsafe to call this method from concurrent goroutines.
+//
+// NOTE: preserved verbatim for compatibility. This only looks at the
+// high 32 bits of maxN and mixes in a package-level Uint32() draw from
+// a different RNG entirely; use RNG.Uint64n for a correct, unbiased
+// result.
+func (r *RNG32) Uint64n(maxN uint64) uint64 {
+	return uint64(r.Uint32n(uint32(maxN>>32)))<<32 | uint64(Uint32())
+}
+
+func (r *RNG32) uint32s(u32 []uint32) {
+	x := r.x
+	for i := range u32 {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		u32[i] = x
+	}
+	r.x = x
+}
@@ -0,0 +1,85 @@
+package rand
+
+import "math/bits"
+
+const pcgMultiplier = 6364136223846793005
+
+// PCG is a PCG XSH RR 64/32 generator: a 64-bit LCG whose state is
+// permuted down to a 32-bit output. Unlike RNG, whose state is only
+// ever seeded from entropy, PCG is meant for reproducible simulation
+// and fuzzing - the same (state, inc) pair always produces the same
+// sequence, and independent streams are obtained by choosing distinct
+// odd inc values.
+//
+// It is unsafe to call PCG methods from concurrent goroutines.
+type PCG struct {
+	state uint64
+	inc   uint64
+}
+
+// Seed initializes the generator with the given state and stream
+// selector. inc picks one of 2^63 independent streams: the increment
+// used internally is inc<<1|1, so it is always odd and the top bit of
+// inc is ignored.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (p *PCG) Seed(state, inc uint64) {
+	p.state = 0
+	p.inc = (inc << 1) | 1
+	p.Uint32()
+	p.state += state
+	p.Uint32()
+}
+
+// Uint32 returns pseudorandom uint32 via the PCG XSH RR 64/32 step.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (p *PCG) Uint32() uint32 {
+	oldstate := p.state
+	p.state = oldstate*pcgMultiplier + (p.inc | 1)
+	xorshifted := uint32(((oldstate >> 18) ^ oldstate) >> 27)
+	rot := uint32(oldstate >> 59)
+	return bits.RotateLeft32(xorshifted, -int(rot))
+}
+
+// Uint64 returns pseudorandom uint64, drawn as two Uint32 steps.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (p *PCG) Uint64() uint64 {
+	return uint64(p.Uint32())<<32 | uint64(p.Uint32())
+}
+
+// Jump advances the generator's state by delta steps in O(log delta)
+// time without generating any intermediate output, letting callers
+// fork deterministic substreams ahead of the current position.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (p *PCG) Jump(delta uint64) {
+	curMult := uint64(pcgMultiplier)
+	curPlus := p.inc | 1
+	accMult := uint64(1)
+	accPlus := uint64(0)
+	for delta > 0 {
+		if delta&1 == 1 {
+			accMult *= curMult
+			accPlus = accPlus*curMult + curPlus
+		}
+		curPlus = (curMult + 1) * curPlus
+		curMult *= curMult
+		delta >>= 1
+	}
+	p.state = accMult*p.state + accPlus
+}
+
+// Save returns the internal state and stream selector, so the
+// generator can be recreated later with Restore.
+func (p *PCG) Save() (state, inc uint64) {
+	return p.state, p.inc
+}
+
+// Restore sets the generator's internal state and stream selector
+// directly, without Seed's warm-up steps.
+func (p *PCG) Restore(state, inc uint64) {
+	p.state = state
+	p.inc = inc
+}
@@ -0,0 +1,68 @@
+package rand
+
+import "testing"
+
+func TestPCGDeterministic(t *testing.T) {
+	var a, b PCG
+	a.Seed(42, 54)
+	b.Seed(42, 54)
+	for i := 0; i < 1000; i++ {
+		if x, y := a.Uint32(), b.Uint32(); x != y {
+			t.Fatalf("draw %d: %d != %d for identical (state, inc)", i, x, y)
+		}
+	}
+}
+
+func TestPCGDistinctStreams(t *testing.T) {
+	var a, b PCG
+	a.Seed(42, 1)
+	b.Seed(42, 3)
+	if a.Uint32() == b.Uint32() {
+		t.Fatal("distinct streams (inc) produced the same first draw")
+	}
+}
+
+// TestPCGJumpMatchesStepping checks that Jump(n) lands on the same
+// state as stepping the generator n times by hand.
+func TestPCGJumpMatchesStepping(t *testing.T) {
+	for _, delta := range []uint64{0, 1, 2, 17, 1000, 1 << 20} {
+		var stepped, jumped PCG
+		stepped.Seed(7, 11)
+		jumped.Seed(7, 11)
+
+		for i := uint64(0); i < delta; i++ {
+			stepped.Uint32()
+		}
+		jumped.Jump(delta)
+
+		sState, sInc := stepped.Save()
+		jState, jInc := jumped.Save()
+		if sState != jState || sInc != jInc {
+			t.Fatalf("delta=%d: stepped state (%d,%d) != jumped state (%d,%d)", delta, sState, sInc, jState, jInc)
+		}
+		if stepped.Uint32() != jumped.Uint32() {
+			t.Fatalf("delta=%d: post-jump draws diverge", delta)
+		}
+	}
+}
+
+func TestPCGSaveRestore(t *testing.T) {
+	var p PCG
+	p.Seed(123, 456)
+	p.Uint32()
+	p.Uint32()
+	state, inc := p.Save()
+
+	var want [10]uint32
+	for i := range want {
+		want[i] = p.Uint32()
+	}
+
+	var restored PCG
+	restored.Restore(state, inc)
+	for i, w := range want {
+		if got := restored.Uint32(); got != w {
+			t.Fatalf("draw %d after restore: got %d, want %d", i, got, w)
+		}
+	}
+}
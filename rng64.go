@@ -0,0 +1,88 @@
+package rand
+
+import "math/bits"
+
+// RNG is a pseudorandom number generator backed by xoshiro256**, a
+// native 64-bit generator. Unlike the legacy RNG32, its Uint64 output
+// is not two concatenated 32-bit draws, and its Uint64n is unbiased.
+//
+// It is unsafe to call RNG methods from concurrent goroutines.
+type RNG struct {
+	s0, s1, s2, s3 uint64
+}
+
+func splitmix64(x uint64) uint64 {
+	z := x + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// ensureSeeded lazily seeds r the first time it is used, so that a
+// bare RNG{} (as opposed to one vended through the package-level
+// functions) is still safe to use directly. It seeds through the same
+// autoSeed path the pool and shards use, i.e. crypto/rand first, so
+// goroutines allocating RNGs in the same instant still get independent
+// state.
+func (r *RNG) ensureSeeded() {
+	if r.s0|r.s1|r.s2|r.s3 != 0 {
+		return
+	}
+	r.autoSeed()
+}
+
+// Uint64 returns pseudorandom uint64.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Uint64() uint64 {
+	r.ensureSeeded()
+	// See https://prng.di.unimi.it/xoshiro256starstar.c
+	result := bits.RotateLeft64(r.s1*5, 7) * 9
+	t := r.s1 << 17
+	r.s2 ^= r.s0
+	r.s3 ^= r.s1
+	r.s1 ^= r.s2
+	r.s0 ^= r.s3
+	r.s2 ^= t
+	r.s3 = bits.RotateLeft64(r.s3, 45)
+	return result
+}
+
+// Uint32 returns pseudorandom uint32, taken from the high bits of
+// Uint64.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Uint32() uint32 {
+	return uint32(r.Uint64() >> 32)
+}
+
+// Uint32n returns pseudorandom uint32 in the range [0..maxN).
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Uint32n(maxN uint32) uint32 {
+	x := r.Uint32()
+	// See http://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/
+	return uint32((uint64(x) * uint64(maxN)) >> 32)
+}
+
+// Uint64n returns pseudorandom uint64 in the range [0..maxN), using
+// Lemire's unbiased reduction.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Uint64n(maxN uint64) uint64 {
+	// See https://lemire.me/blog/2016/06/30/fast-random-shuffling/
+	hi, lo := bits.Mul64(r.Uint64(), maxN)
+	if lo < maxN {
+		t := -maxN % maxN
+		for lo < t {
+			hi, lo = bits.Mul64(r.Uint64(), maxN)
+		}
+	}
+	return hi
+}
+
+func (r *RNG) uint32s(u32 []uint32) {
+	for i := range u32 {
+		u32[i] = r.Uint32()
+	}
+}
@@ -0,0 +1,56 @@
+package rand
+
+import "testing"
+
+func TestRNGUint64nBounds(t *testing.T) {
+	var r RNG
+	r.Seed([32]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	for _, n := range []uint64{1, 2, 3, 7, 1 << 16, 1<<32 - 1, 1 << 32, 1<<63 + 7} {
+		for i := 0; i < 10000; i++ {
+			if x := r.Uint64n(n); x >= n {
+				t.Fatalf("Uint64n(%d) = %d, want < %d", n, x, n)
+			}
+		}
+	}
+}
+
+func TestRNGUint64nUniform(t *testing.T) {
+	var r RNG
+	r.Seed([32]byte{9, 9, 9})
+	const n = 3
+	var counts [n]int
+	const trials = 300000
+	for i := 0; i < trials; i++ {
+		counts[r.Uint64n(n)]++
+	}
+	for i, c := range counts {
+		if c < trials/n/2 || c > trials/n*3/2 {
+			t.Fatalf("bucket %d got %d draws out of %d, want roughly %d", i, c, trials, trials/n)
+		}
+	}
+}
+
+func TestRNGDeterministicFromSeed(t *testing.T) {
+	seed := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	var a, b RNG
+	a.Seed(seed)
+	b.Seed(seed)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("draw %d: %d != %d for identical seeds", i, x, y)
+		}
+	}
+}
+
+func TestRNGDifferentSeedsDiverge(t *testing.T) {
+	// The xoshiro256** output formula is a function of s1 alone before
+	// any state mixing, so seeds must differ in the bytes that become
+	// s1 (index 8..15) for their very first draw to be expected to
+	// differ too.
+	var a, b RNG
+	a.Seed([32]byte{0, 0, 0, 0, 0, 0, 0, 0, 1})
+	b.Seed([32]byte{0, 0, 0, 0, 0, 0, 0, 0, 2})
+	if a.Uint64() == b.Uint64() {
+		t.Fatal("distinct seeds produced the same first draw")
+	}
+}
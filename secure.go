@@ -0,0 +1,185 @@
+package rand
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/bits"
+	"sync"
+)
+
+// ChaCha8 constants, the ASCII bytes of "expand 32-byte k" split into
+// four little-endian words.
+const (
+	chachaConst0 = 0x61707865
+	chachaConst1 = 0x3320646e
+	chachaConst2 = 0x79622d32
+	chachaConst3 = 0x6b206574
+)
+
+// SecureRNG is a pseudorandom number generator backed by a pure-Go
+// ChaCha8 stream. Unlike RNG, it is not predictable from a handful of
+// observed outputs, so it is safe to reach for in security-sensitive
+// code paths that would otherwise need crypto/rand directly.
+//
+// It is unsafe to call SecureRNG methods from concurrent goroutines.
+type SecureRNG struct {
+	key   [8]uint32
+	ctr   [2]uint32
+	nonce [2]uint32
+	block [16]uint32 // last generated ChaCha8 block
+	used  int        // number of uint64s already drained from block
+}
+
+// outputWords64 is how many uint64s of each 64-byte block are handed
+// to callers as output. The remaining half (block[8:16], the last 32
+// bytes) is reserved to become the next key and is never output -
+// otherwise a caller who has seen one full block could read off the
+// next key directly from its own draws.
+const outputWords64 = 4
+
+var securePool = sync.Pool{
+	New: func() interface{} {
+		r := new(SecureRNG)
+		var seed [32]byte
+		if _, err := rand.Read(seed[:]); err != nil {
+			panic("fastrand: failed to read from crypto/rand: " + err.Error())
+		}
+		r.Seed(seed)
+		return r
+	},
+}
+
+// Seed resets the generator to the ChaCha8 stream keyed by key.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *SecureRNG) Seed(key [32]byte) {
+	for i := 0; i < 8; i++ {
+		r.key[i] = binary.LittleEndian.Uint32(key[i*4 : i*4+4])
+	}
+	r.ctr = [2]uint32{}
+	r.nonce = [2]uint32{}
+	r.used = outputWords64 // force a refill before the first draw
+}
+
+func chachaQuarterRound(a, b, c, d *uint32) {
+	*a += *b
+	*d ^= *a
+	*d = bits.RotateLeft32(*d, 16)
+	*c += *d
+	*b ^= *c
+	*b = bits.RotateLeft32(*b, 12)
+	*a += *b
+	*d ^= *a
+	*d = bits.RotateLeft32(*d, 8)
+	*c += *d
+	*b ^= *c
+	*b = bits.RotateLeft32(*b, 7)
+}
+
+// refill runs the ChaCha8 core over the current key/counter/nonce and
+// buffers the resulting block. Only the first outputWords64 uint64s of
+// the block (the first 32 bytes) are ever handed out by nextUint64;
+// the last 32 bytes become the next key and are kept secret, so the
+// next refill is unpredictable even to an observer who has seen every
+// word this refill actually output (the "chacha8rand" construction).
+func (r *SecureRNG) refill() {
+	var state [16]uint32
+	state[0], state[1], state[2], state[3] = chachaConst0, chachaConst1, chachaConst2, chachaConst3
+	copy(state[4:12], r.key[:])
+	state[12], state[13] = r.ctr[0], r.ctr[1]
+	state[14], state[15] = r.nonce[0], r.nonce[1]
+
+	working := state
+	for i := 0; i < 4; i++ {
+		chachaQuarterRound(&working[0], &working[4], &working[8], &working[12])
+		chachaQuarterRound(&working[1], &working[5], &working[9], &working[13])
+		chachaQuarterRound(&working[2], &working[6], &working[10], &working[14])
+		chachaQuarterRound(&working[3], &working[7], &working[11], &working[15])
+		chachaQuarterRound(&working[0], &working[5], &working[10], &working[15])
+		chachaQuarterRound(&working[1], &working[6], &working[11], &working[12])
+		chachaQuarterRound(&working[2], &working[7], &working[8], &working[13])
+		chachaQuarterRound(&working[3], &working[4], &working[9], &working[14])
+	}
+	for i := range working {
+		working[i] += state[i]
+	}
+
+	r.block = working
+	copy(r.key[:], working[8:16])
+	r.ctr[0]++
+	if r.ctr[0] == 0 {
+		r.ctr[1]++
+	}
+	r.used = 0
+}
+
+func (r *SecureRNG) nextUint64() uint64 {
+	if r.used >= outputWords64 {
+		r.refill()
+	}
+	i := r.used
+	r.used++
+	return uint64(r.block[i*2]) | uint64(r.block[i*2+1])<<32
+}
+
+// Uint64 returns a pseudorandom, non-predictable uint64.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *SecureRNG) Uint64() uint64 {
+	return r.nextUint64()
+}
+
+// Uint32 returns a pseudorandom, non-predictable uint32.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *SecureRNG) Uint32() uint32 {
+	return uint32(r.nextUint64())
+}
+
+// Read fills p with non-predictable bytes drawn from the ChaCha8
+// stream. It always returns len(p) and a nil error, matching
+// crypto/rand.Read at higher throughput.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *SecureRNG) Read(p []byte) (int, error) {
+	n := len(p)
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, r.nextUint64())
+		p = p[8:]
+	}
+	if len(p) > 0 {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], r.nextUint64())
+		copy(p, buf[:])
+	}
+	return n, nil
+}
+
+// SecureUint32 returns a pseudorandom, non-predictable uint32.
+//
+// It is safe calling this function from concurrent goroutines.
+func SecureUint32() uint32 {
+	r := securePool.Get().(*SecureRNG)
+	defer securePool.Put(r)
+	return r.Uint32()
+}
+
+// SecureUint64 returns a pseudorandom, non-predictable uint64.
+//
+// It is safe calling this function from concurrent goroutines.
+func SecureUint64() uint64 {
+	r := securePool.Get().(*SecureRNG)
+	defer securePool.Put(r)
+	return r.Uint64()
+}
+
+// SecureRead generates len(p) non-predictable random bytes from a
+// package-level ChaCha8 stream seeded from crypto/rand, and writes
+// them into p. It always returns len(p) and a nil error. SecureRead is
+// a drop-in replacement for crypto/rand.Read at higher throughput, and
+// is safe for concurrent use.
+func SecureRead(p []byte) (int, error) {
+	r := securePool.Get().(*SecureRNG)
+	defer securePool.Put(r)
+	return r.Read(p)
+}
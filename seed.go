@@ -0,0 +1,79 @@
+package rand
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"os"
+	"sync/atomic"
+	"time"
+	_ "unsafe" // for go:linkname
+)
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+// seedCounter is folded into the fallback seed so that goroutines
+// allocating an RNG within the same nanosecond still get distinct
+// state.
+var seedCounter uint64
+
+// autoseedDisabled mirrors math/rand's randautoseed GODEBUG knob: set
+// the environment variable fastrand_autoseed=0 to skip crypto/rand and
+// seed each new RNG from the global counter alone, so a program that
+// creates its RNGs in the same order gets the same seeds on every run,
+// e.g. for reproducible benchmarks.
+var autoseedDisabled = os.Getenv("fastrand_autoseed") == "0"
+
+// Seed resets r to start from the given 32-byte seed.
+//
+// It is unsafe to call this method from concurrent goroutines.
+func (r *RNG) Seed(seed [32]byte) {
+	r.s0 = binary.LittleEndian.Uint64(seed[0:8])
+	r.s1 = binary.LittleEndian.Uint64(seed[8:16])
+	r.s2 = binary.LittleEndian.Uint64(seed[16:24])
+	r.s3 = binary.LittleEndian.Uint64(seed[24:32])
+}
+
+// autoSeed seeds r from crypto/rand, falling back to a mix of wall
+// clock, monotonic clock and a global counter (via splitmix64) only if
+// the OS read fails. When autoseeding was disabled via
+// fastrand_autoseed, it seeds from the global counter alone (via
+// splitmix64), with no clock input.
+func (r *RNG) autoSeed() {
+	if autoseedDisabled {
+		x := atomic.AddUint64(&seedCounter, 1)
+		var seed [32]byte
+		for i := 0; i < 4; i++ {
+			x = splitmix64(x)
+			binary.LittleEndian.PutUint64(seed[i*8:], x)
+		}
+		r.Seed(seed)
+		return
+	}
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err == nil {
+		r.Seed(seed)
+		return
+	}
+	r.Seed(fallbackSeed())
+}
+
+func fallbackSeed() [32]byte {
+	x := uint64(time.Now().UnixNano()) ^ uint64(nanotime())
+	x ^= atomic.AddUint64(&seedCounter, 1)
+
+	var seed [32]byte
+	for i := 0; i < 4; i++ {
+		x = splitmix64(x)
+		binary.LittleEndian.PutUint64(seed[i*8:], x)
+	}
+	return seed
+}
+
+// newAutoSeededRNG returns an RNG already seeded per autoSeed, for use
+// as the initial state of pooled or sharded RNGs.
+func newAutoSeededRNG() *RNG {
+	r := new(RNG)
+	r.autoSeed()
+	return r
+}
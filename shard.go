@@ -0,0 +1,71 @@
+//go:build gc
+
+package rand
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+//go:linkname procPin runtime.procPin
+func procPin() int
+
+//go:linkname procUnpin runtime.procUnpin
+func procUnpin()
+
+// rngShard pads an RNG out to a cache line so that two Ps hammering
+// neighboring shards never bounce the same cache line between cores.
+//
+// mu guards the RNG itself. procPin only prevents the calling
+// goroutine's own P from running a second goroutine through this same
+// shard at the same instant; it gives no happens-before guarantee
+// between two goroutines that use the shard at different times (the
+// race detector, and the memory model it enforces, agree that without
+// mu a later reader is not guaranteed to observe an earlier writer's
+// updates). The mutex is uncontended in the common case of one
+// goroutine per P, so this stays far cheaper than the sync.Pool it
+// replaces.
+type rngShard struct {
+	mu sync.Mutex
+	RNG
+	_ [64 - (unsafe.Sizeof(sync.Mutex{})+unsafe.Sizeof(RNG{}))%64]byte
+}
+
+// shards holds one RNG per P known at startup. runtime.GOMAXPROCS can
+// still be raised afterwards; a P beyond len(shards) falls back to
+// shardFallback below rather than growing this slice, since resizing
+// it concurrently with the reads in acquireRNG would itself be a race.
+var shards = func() []rngShard {
+	s := make([]rngShard, runtime.GOMAXPROCS(0))
+	for i := range s {
+		s[i].RNG = *newAutoSeededRNG()
+	}
+	return s
+}()
+
+var shardFallback = sync.Pool{
+	New: func() interface{} { return newAutoSeededRNG() },
+}
+
+// acquireRNG returns the calling goroutine's per-P shard, or an RNG
+// from the fallback pool if its P didn't exist when shards was sized,
+// along with a release function. procPin/procUnpin bound how long the
+// shard's mutex can possibly be held for (nothing can preempt the
+// holder out from under it); callers must not hold the returned RNG
+// across unbounded work regardless, since procPin also disables
+// preemption for the calling goroutine until released.
+func acquireRNG() (*RNG, func()) {
+	pid := procPin()
+	if pid < len(shards) {
+		s := &shards[pid]
+		s.mu.Lock()
+		return &s.RNG, func() {
+			s.mu.Unlock()
+			procUnpin()
+		}
+	}
+	procUnpin()
+	r := shardFallback.Get().(*RNG)
+	return r, func() { shardFallback.Put(r) }
+}
@@ -0,0 +1,18 @@
+//go:build !gc
+
+package rand
+
+import "sync"
+
+// rngPool is the fallback backing for acquireRNG on toolchains
+// where runtime.procPin cannot be linknamed (e.g. gccgo).
+var rngPool = sync.Pool{
+	New: func() interface{} {
+		return newAutoSeededRNG()
+	},
+}
+
+func acquireRNG() (*RNG, func()) {
+	r := rngPool.Get().(*RNG)
+	return r, func() { rngPool.Put(r) }
+}
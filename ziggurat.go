@@ -0,0 +1,123 @@
+package rand
+
+import "math"
+
+// zigLayers is the number of rectangular ziggurat layers used for
+// NormFloat64 and ExpFloat64; a base strip holding the tail sits below
+// them, for zigLayers+1 equal-area layers in all.
+const zigLayers = 128
+
+var (
+	normX [zigLayers + 1]float64
+	normF [zigLayers + 1]float64
+	expX  [zigLayers + 1]float64
+	expF  [zigLayers + 1]float64
+)
+
+func init() {
+	buildZiggurat(normX[:], normF[:], normPDF, normPDFInv, normTailArea, 0.1, 12)
+	buildZiggurat(expX[:], expF[:], expPDF, expPDFInv, expTailArea, 0.1, 40)
+}
+
+func normPDF(x float64) float64    { return math.Exp(-x * x / 2) }
+func normPDFInv(y float64) float64 { return math.Sqrt(-2 * math.Log(y)) }
+func normTailArea(r float64) float64 {
+	return math.Sqrt(math.Pi/2) * math.Erfc(r/math.Sqrt2)
+}
+
+func expPDF(x float64) float64      { return math.Exp(-x) }
+func expPDFInv(y float64) float64   { return -math.Log(y) }
+func expTailArea(r float64) float64 { return math.Exp(-r) }
+
+// buildZiggurat fills x and y (the layer boundaries and pdf(x) at
+// those boundaries) for the Marsaglia-Tsang ziggurat of the
+// monotonically decreasing density pdf on [0, +Inf), with inverse
+// pdfInv and tail mass tailArea(r) = integral from r to +Inf of pdf.
+// It bisects for the tail start r over [lo, hi] such that every layer,
+// including the tail, has equal area.
+func buildZiggurat(x, y []float64, pdf, pdfInv func(float64) float64, tailArea func(float64) float64, lo, hi float64) {
+	n := len(x) - 1
+	f0 := pdf(0)
+	var r float64
+	for iter := 0; iter < 200; iter++ {
+		r = (lo + hi) / 2
+		v := r*pdf(r) + tailArea(r)
+		x[n], y[n] = r, pdf(r)
+		valid := true
+		for i := n - 1; i >= 1; i-- {
+			y[i] = y[i+1] + v/x[i+1]
+			if y[i] >= f0 {
+				valid = false
+				break
+			}
+			x[i] = pdfInv(y[i])
+		}
+		if !valid {
+			lo = r
+			continue
+		}
+		if g := x[1]*(f0-y[1]) - v; g > 0 {
+			hi = r
+		} else {
+			lo = r
+		}
+	}
+
+	r = (lo + hi) / 2
+	v := r*pdf(r) + tailArea(r)
+	x[n], y[n] = r, pdf(r)
+	for i := n - 1; i >= 1; i-- {
+		y[i] = y[i+1] + v/x[i+1]
+		x[i] = pdfInv(y[i])
+	}
+	x[0], y[0] = 0, f0
+}
+
+// ziggurat draws a sample from the one-sided density described by x,
+// f (pdf(x[i]) for each layer boundary) and pdf, using rejection
+// sampling from the corresponding ziggurat layers and tailSample for
+// the infinite tail beyond x[len(x)-1]. Layer 0 is the base strip
+// [0, x[n]] x [0, f[n]] together with the tail.
+func ziggurat(r *RNG, x, f []float64, pdf func(float64) float64, tailSample func(*RNG, float64) float64) float64 {
+	n := len(x) - 1
+	for {
+		i := int(r.Uint32n(uint32(n + 1)))
+		if i == 0 {
+			// The base strip has the same area as a layer, so its width
+			// as a rectangle of height f[n] is that area over f[n].
+			v := r.Float64() * x[n] * (f[n-1] - f[n]) / f[n]
+			if v < x[n] {
+				return v
+			}
+			return tailSample(r, x[n])
+		}
+		v := r.Float64() * x[i]
+		if v < x[i-1] {
+			return v
+		}
+		fy := f[i] + r.Float64()*(f[i-1]-f[i])
+		if fy < pdf(v) {
+			return v
+		}
+	}
+}
+
+// normTailSample draws from the tail of the half-normal density beyond
+// r, using the standard Marsaglia rejection method for that region.
+func normTailSample(r *RNG, x float64) float64 {
+	for {
+		u1 := r.Float64()
+		u2 := r.Float64()
+		t := -math.Log(u1) / x
+		e := -math.Log(u2)
+		if e+e > t*t {
+			return x + t
+		}
+	}
+}
+
+// expTailSample draws from the tail of the exponential density beyond
+// r, which is itself exponential by the memoryless property.
+func expTailSample(r *RNG, x float64) float64 {
+	return x - math.Log(r.Float64())
+}